@@ -0,0 +1,130 @@
+package zlog
+
+import (
+	"fmt"
+	"log"
+	"os"
+	"sync"
+	"time"
+)
+
+const _maxSinkRetry = 3
+
+// asyncSinkWriter 把日志行投进一个有界 channel，后台协程攒够一批或每秒钟就调用
+// sinkBackend.Send 批量发送一次，失败按指数退避重试几次后放弃（避免瞬时抖动就丢数据，
+// 但也不会无限阻塞主日志链路）。channel 满了之后按 Backpressure 处理：block 阻塞写入方，
+// drop 直接丢弃这条，spill 退化为把日志行追加写到 spillFile，这样主链路不会被下游抖动卡死
+// 或悄悄丢数据。
+type asyncSinkWriter struct {
+	backend      sinkBackend
+	ch           chan string
+	backpressure string
+	spillFile    string
+
+	spillMu sync.Mutex
+	spill   *os.File
+}
+
+func newAsyncSinkWriter(backend sinkBackend, bufferSize int, backpressure, spillFile string) *asyncSinkWriter {
+	if bufferSize <= 0 {
+		bufferSize = 1000
+	}
+	w := &asyncSinkWriter{
+		backend:      backend,
+		ch:           make(chan string, bufferSize),
+		backpressure: backpressure,
+		spillFile:    spillFile,
+	}
+	go w.run()
+	return w
+}
+
+// Write 实现 zapcore.WriteSyncer，按配置的背压策略把日志行投进发送队列。
+func (w *asyncSinkWriter) Write(p []byte) (int, error) {
+	line := string(p)
+	switch w.backpressure {
+	case _backpressureDrop:
+		select {
+		case w.ch <- line:
+		default:
+		}
+	case _backpressureSpill:
+		select {
+		case w.ch <- line:
+		default:
+			w.spillToDisk(line)
+		}
+	default: // block
+		w.ch <- line
+	}
+	return len(p), nil
+}
+
+// Sync 队列和发送都已经是异步的，这里无需额外动作。
+func (w *asyncSinkWriter) Sync() error {
+	return nil
+}
+
+// run 每秒或每攒够 64 条批量调用一次 backend.Send。
+func (w *asyncSinkWriter) run() {
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+	var batch []string
+	flush := func() {
+		if len(batch) == 0 {
+			return
+		}
+		w.send(batch)
+		batch = nil
+	}
+	for {
+		select {
+		case line := <-w.ch:
+			batch = append(batch, line)
+			if len(batch) >= 64 {
+				flush()
+			}
+		case <-ticker.C:
+			flush()
+		}
+	}
+}
+
+// send 把一批日志行发给 backend，失败按指数退避重试几次，仍然失败才放弃（避免瞬时抖动就丢数据）。
+func (w *asyncSinkWriter) send(batch []string) {
+	backoff := time.Second
+	for attempt := 1; attempt <= _maxSinkRetry; attempt++ {
+		if err := w.backend.Send(batch); err != nil {
+			log.Printf("zlog: sink send failed (attempt %d/%d): %v", attempt, _maxSinkRetry, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+		return
+	}
+}
+
+// spillToDisk 在 channel 满且策略为 spill 时，把日志行追加写到本地溢出文件，避免直接丢数据。
+// 加锁保护 w.spill：虽然 buildSinkCore 会用 zapcore.Lock 包一层串行化 Write，这里额外加锁是
+// 防御性的，不依赖调用方一定这么做。
+func (w *asyncSinkWriter) spillToDisk(line string) {
+	w.spillMu.Lock()
+	defer w.spillMu.Unlock()
+	if w.spill == nil {
+		f, err := os.OpenFile(w.spillFile, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0644)
+		if err != nil {
+			log.Printf("zlog: open spill file failed: %v", err)
+			return
+		}
+		w.spill = f
+	}
+	if _, err := w.spill.WriteString(line); err != nil {
+		log.Printf("zlog: write spill file failed: %v", err)
+	}
+}
+
+// defaultSinkSpillFile 为每个 sink 生成各自独立的溢出文件名，避免多个 spilling sink
+// 共用一个文件导致输出交错、互相覆盖。
+func defaultSinkSpillFile(sinkType string, index int) string {
+	return fmt.Sprintf("zlog_sink_spill_%s_%d.log", sinkType, index)
+}
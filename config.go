@@ -1,14 +1,12 @@
 package zlog
 
 import (
-	"fmt"
 	"github.com/luxun9527/zlog/report"  // 自定义的日志上报模块
 	"github.com/mitchellh/mapstructure" // 用于将 map 转换为结构体
 	"go.uber.org/zap"                   // 高性能的日志库
 	"go.uber.org/zap/zapcore"           // zap 的核心模块
 	"gopkg.in/natefinch/lumberjack.v2"  // 用于日志文件分割
 	"log"
-	"net/http" // 用于启动 HTTP 服务
 	"os"
 	"reflect" // 用于反射类型检查
 	"sync"    // 用于并发安全
@@ -25,31 +23,71 @@ const (
 	_console = "console" // 控制台模式
 )
 
+const (
+	_rotateBySize = "size" // 仅按大小/备份数切割（lumberjack 默认行为）
+	_rotateByTime = "time" // 仅按时间切割
+	_rotateByBoth = "both" // 按大小和时间共同切割
+
+	_timeOnlyMaxSize = 1 << 30 // rotateBy=time 时给 lumberjack 的 MaxSize（MB），大到不会触发按大小切割
+)
+
 var (
 	_once sync.Once // 确保日志服务器只初始化一次
 )
 
+// rotatable 是能被 /rotate 管理接口强制切割的写入器，*lumberjack.Logger 和 *timedRotateWriter
+// 都天然实现了这个接口。
+type rotatable interface {
+	Rotate() error
+}
+
 // 日志配置结构体
 type Config struct {
-	Name          string               `json:",optional" mapstructure:"name"`           // 日志项目名称
-	Level         zap.AtomicLevel      `json:"Level" mapstructure:"level"`              // 日志级别
-	Stacktrace    bool                 `json:",default=true" mapstructure:"stacktrace"` // 是否显示堆栈
-	AddCaller     bool                 `json:",default=true" mapstructure:"addCaller"`  // 是否显示调用者信息
-	CallerShip    int                  `json:",default=3" mapstructure:"callerShip"`    // 调用链级别
-	Mode          string               `json:",default=console" mapstructure:"mode"`    // 输出模式，console 或 file
-	FileName      string               `json:",optional" mapstructure:"filename"`       // 日志文件名
-	ErrorFileName string               `json:",optional" mapstructure:"errorFileName"`  // 错误日志文件名
-	MaxSize       int                  `json:",optional" mapstructure:"maxSize"`        // 日志文件最大大小 (MB)
-	MaxAge        int                  `json:",optional" mapstructure:"maxAge"`         // 日志保留天数
-	MaxBackup     int                  `json:",optional" mapstructure:"maxBackUp"`      // 日志最大备份数
-	Async         bool                 `json:",optional" mapstructure:"async"`          // 是否异步日志
-	Json          bool                 `json:",optional" mapstructure:"json"`           // 是否输出 JSON 格式
-	Compress      bool                 `json:",optional" mapstructure:"compress"`       // 是否压缩日志
-	Console       bool                 `json:"console" mapstructure:"console"`          // 是否在 file 模式下同时输出到控制台
-	Color         bool                 `json:",default=true" mapstructure:"color"`      // 非 JSON 格式下是否添加颜色
-	Port          int32                `json:",default=true" mapstructure:"port"`       // 启动日志 HTTP 服务的端口
-	ReportConfig  *report.ReportConfig `json:",optional" mapstructure:"reportConfig"`   // 日志上报配置
-	options       []zap.Option         // zap 选项
+	Name           string               `json:",optional" mapstructure:"name"`           // 日志项目名称
+	Level          zap.AtomicLevel      `json:"Level" mapstructure:"level"`              // 日志级别
+	Stacktrace     bool                 `json:",default=true" mapstructure:"stacktrace"` // 是否显示堆栈
+	AddCaller      bool                 `json:",default=true" mapstructure:"addCaller"`  // 是否显示调用者信息
+	CallerShip     int                  `json:",default=3" mapstructure:"callerShip"`    // 调用链级别
+	Mode           string               `json:",default=console" mapstructure:"mode"`    // 输出模式，console 或 file
+	FileName       string               `json:",optional" mapstructure:"filename"`       // 日志文件名
+	ErrorFileName  string               `json:",optional" mapstructure:"errorFileName"`  // 错误日志文件名
+	MaxSize        int                  `json:",optional" mapstructure:"maxSize"`        // 日志文件最大大小 (MB)
+	MaxAge         int                  `json:",optional" mapstructure:"maxAge"`         // 日志保留天数
+	MaxBackup      int                  `json:",optional" mapstructure:"maxBackUp"`      // 日志最大备份数
+	Async          bool                 `json:",optional" mapstructure:"async"`          // 是否异步日志
+	Json           bool                 `json:",optional" mapstructure:"json"`           // 是否输出 JSON 格式
+	Compress       bool                 `json:",optional" mapstructure:"compress"`       // 是否压缩日志
+	Console        bool                 `json:"console" mapstructure:"console"`          // 是否在 file 模式下同时输出到控制台
+	Color          bool                 `json:",default=true" mapstructure:"color"`      // 非 JSON 格式下是否添加颜色
+	Port           int32                `json:",default=true" mapstructure:"port"`       // 启动日志 HTTP 服务的端口
+	ReportConfig   *report.ReportConfig `json:",optional" mapstructure:"reportConfig"`   // 日志上报配置
+	RotateBy       string               `json:",default=size" mapstructure:"rotateBy"`   // 切割方式，size(默认)|time|both
+	RotateInterval time.Duration        `json:",optional" mapstructure:"rotateInterval"` // 按时间切割的间隔，如 1h、24h，与 RotateAt 二选一
+	RotateAt       string               `json:",optional" mapstructure:"rotateAt"`       // 按时间切割的固定时间点，格式 HH:MM，优先级高于 RotateInterval
+	LogServerAddr  string               `json:",optional" mapstructure:"logServerAddr"`  // 日志管理服务监听地址，默认 127.0.0.1
+	LogServerToken string               `json:",optional" mapstructure:"logServerToken"` // 日志管理服务的 bearer token，留空则不鉴权
+	Sampling       *SamplingConfig      `json:",optional" mapstructure:"sampling"`       // 采样配置，降低突发重复日志的写入量
+	RateLimit      *RateLimitConfig     `json:",optional" mapstructure:"rateLimit"`      // 限流配置，防止热循环刷爆磁盘/上报后端
+	Sinks          []SinkConfig         `json:",optional" mapstructure:"sinks"`          // 除 console/file 外的额外输出，如 kafka、loki、otlp
+	options        []zap.Option         // zap 选项
+	rotators       []rotatable          // Build 里实际创建的、可被 /rotate 接口强制切割的写入器
+}
+
+// SamplingConfig 对应 zapcore.NewSamplerWithOptions 的参数：每个 Tick 周期内，
+// 同一条消息前 Initial 条全部记录，之后每 Thereafter 条才记录一条，用于压低热点路径的日志量。
+type SamplingConfig struct {
+	Initial    int           `json:",default=100" mapstructure:"initial"`
+	Thereafter int           `json:",default=100" mapstructure:"thereafter"`
+	Tick       time.Duration `json:",default=1s" mapstructure:"tick"`
+}
+
+// RateLimitConfig 限流配置。日志按 PerKey 指定的字段取值（留空则按消息本身）分桶，
+// 每个桶是一个容量为 Burst、按 PerSecond 匀速补充的令牌桶，超出速率的日志会被直接丢弃，
+// 避免热循环里反复出现的相同错误把磁盘或上报后端打爆。
+type RateLimitConfig struct {
+	PerSecond int    `json:",default=100" mapstructure:"perSecond"`
+	Burst     int    `json:",default=100" mapstructure:"burst"`
+	PerKey    string `json:",optional" mapstructure:"perKey"`
 }
 
 // 更新日志级别
@@ -57,6 +95,44 @@ func (lc *Config) UpdateLevel(level zapcore.Level) {
 	lc.Level.SetLevel(level)
 }
 
+// _redacted 替换掉敏感字段后展示给管理接口调用方看的占位符。
+const _redacted = "***"
+
+// redacted 返回一份浅拷贝的 Config，抹掉 LogServerToken、上报渠道的 Secret/Token/Webhook
+// 以及 Kafka sink 的 SASLPass，供 /config 管理接口对外展示，避免把鉴权用的凭证明文吐出去。
+func (lc *Config) redacted() *Config {
+	cp := *lc
+	if cp.LogServerToken != "" {
+		cp.LogServerToken = _redacted
+	}
+	if lc.ReportConfig != nil {
+		reportCopy := *lc.ReportConfig
+		if reportCopy.Webhook != "" {
+			reportCopy.Webhook = _redacted
+		}
+		if reportCopy.Secret != "" {
+			reportCopy.Secret = _redacted
+		}
+		if reportCopy.Token != "" {
+			reportCopy.Token = _redacted
+		}
+		cp.ReportConfig = &reportCopy
+	}
+	if len(lc.Sinks) > 0 {
+		sinksCopy := make([]SinkConfig, len(lc.Sinks))
+		copy(sinksCopy, lc.Sinks)
+		for i := range sinksCopy {
+			if sinksCopy[i].Kafka != nil && sinksCopy[i].Kafka.SASLPass != "" {
+				kafkaCopy := *sinksCopy[i].Kafka
+				kafkaCopy.SASLPass = _redacted
+				sinksCopy[i].Kafka = &kafkaCopy
+			}
+		}
+		cp.Sinks = sinksCopy
+	}
+	return &cp
+}
+
 // 构建日志对象
 func (lc *Config) Build() *zap.Logger {
 	if lc.Mode != _file && lc.Mode != _console {
@@ -108,9 +184,29 @@ func (lc *Config) Build() *zap.Logger {
 				LocalTime:  true,
 				Compress:   lc.Compress,
 			}
-			errorWs = zapcore.Lock(zapcore.AddSync(errorConfig))
+			if lc.RotateBy == _rotateByTime || lc.RotateBy == _rotateByBoth {
+				if lc.RotateBy == _rotateByTime {
+					errorConfig.MaxSize = _timeOnlyMaxSize
+				}
+				timedErrorWriter := newTimedRotateWriter(errorConfig, lc.RotateInterval, lc.RotateAt)
+				lc.rotators = append(lc.rotators, timedErrorWriter)
+				errorWs = zapcore.Lock(timedErrorWriter)
+			} else {
+				lc.rotators = append(lc.rotators, errorConfig)
+				errorWs = zapcore.Lock(zapcore.AddSync(errorConfig))
+			}
+		}
+		if lc.RotateBy == _rotateByTime || lc.RotateBy == _rotateByBoth {
+			if lc.RotateBy == _rotateByTime {
+				normalConfig.MaxSize = _timeOnlyMaxSize
+			}
+			timedWriter := newTimedRotateWriter(normalConfig, lc.RotateInterval, lc.RotateAt)
+			lc.rotators = append(lc.rotators, timedWriter)
+			ws = zapcore.Lock(timedWriter)
+		} else {
+			lc.rotators = append(lc.rotators, normalConfig)
+			ws = zapcore.Lock(zapcore.AddSync(normalConfig))
 		}
-		ws = zapcore.Lock(zapcore.AddSync(normalConfig))
 	}
 
 	// 非 JSON 格式是否加颜色
@@ -139,15 +235,31 @@ func (lc *Config) Build() *zap.Logger {
 		}
 	}
 
-	var cores = []zapcore.Core{zapcore.NewCore(encoder, ws, lc.Level)}
+	// 限流：状态在所有子 core 之间共享，但必须套在每个子 core 各自外面（而不是套在合并后的
+	// Tee 外面）。zapcore.Tee 的 Write 不做任何级别过滤——过滤只发生在 Check 阶段，
+	// Check 收集到的实际子 core 列表才是后面 Write 会用到的；如果把 rateLimitCore 套在
+	// Tee 外层，Write 会直接转发给 Tee.Write，对所有子 core（包括级别更高的错误文件/
+	// 上报 core）无差别写入，等于绕过了它们各自的 LevelEnabler。
+	var rlState *rateLimitState
+	if lc.RateLimit != nil {
+		rlState = newRateLimitState(lc.RateLimit)
+	}
+	wrapLeaf := func(leaf zapcore.Core) zapcore.Core {
+		if rlState != nil {
+			return rlState.wrap(leaf)
+		}
+		return leaf
+	}
+
+	var cores = []zapcore.Core{wrapLeaf(zapcore.NewCore(encoder, ws, lc.Level))}
 	if errorWs != nil {
-		highCore := zapcore.NewCore(encoder, errorWs, zapcore.ErrorLevel)
+		highCore := wrapLeaf(zapcore.NewCore(encoder, errorWs, zapcore.ErrorLevel))
 		cores = append(cores, highCore)
 	}
 
 	// 文件模式输出到控制台
 	if lc.Mode == _file && lc.Console {
-		consoleCore := zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), lc.Level)
+		consoleCore := wrapLeaf(zapcore.NewCore(encoder, zapcore.Lock(os.Stdout), lc.Level))
 		cores = append(cores, consoleCore)
 	}
 
@@ -160,11 +272,31 @@ func (lc *Config) Build() *zap.Logger {
 		if lc.ReportConfig.Level == (zap.AtomicLevel{}) {
 			lc.ReportConfig.Level = zap.NewAtomicLevelAt(zap.WarnLevel)
 		}
-		reportCore := zapcore.NewCore(encoder, report.NewReportWriterBuffer(lc.ReportConfig), lc.ReportConfig.Level)
+		reportCore := wrapLeaf(zapcore.NewCore(encoder, report.NewReportWriterBuffer(lc.ReportConfig), lc.ReportConfig.Level))
 		cores = append(cores, reportCore)
 	}
 
+	// 额外的 Kafka/Loki/OTLP sinks，和本地文件/控制台日志一起通过 Tee 合并输出
+	for _, sinkConf := range lc.Sinks {
+		sinkCore, err := buildSinkCore(sinkConf, encoder, lc.Level)
+		if err != nil {
+			log.Printf("zlog: build sink %q failed: %v", sinkConf.Type, err)
+			continue
+		}
+		cores = append(cores, wrapLeaf(sinkCore))
+	}
+
 	core := zapcore.NewTee(cores...) // 合并日志核心
+
+	// 采样：压低突发重复日志的写入量
+	if lc.Sampling != nil {
+		tick := lc.Sampling.Tick
+		if tick <= 0 {
+			tick = time.Second
+		}
+		core = zapcore.NewSamplerWithOptions(core, tick, lc.Sampling.Initial, lc.Sampling.Thereafter)
+	}
+
 	logger := zap.New(core)
 
 	// 添加调用者信息
@@ -196,17 +328,6 @@ func (lc *Config) Build() *zap.Logger {
 	return logger
 }
 
-// 初始化日志 HTTP 服务
-func (lc *Config) InitLogServer(port int32) {
-	go func(p int32) {
-		_once.Do(func() { // 确保只初始化一次
-			if err := http.ListenAndServe(fmt.Sprintf("127.0.0.1:%d", p), lc.Level); err != nil {
-				zap.S().Error("init log server start failed", zap.Error(err))
-			}
-		})
-	}(port)
-}
-
 func CustomTimeEncoder(t time.Time, enc zapcore.PrimitiveArrayEncoder) {
 	enc.AppendString(t.Format("2006-01-02-15:04:05"))
 }
@@ -7,6 +7,9 @@ import (
 	"time"
 )
 
+// _moduleGin 是 GinOutPut 在日志管理服务里注册的模块名，对应 PUT /levels/gin。
+const _moduleGin = "gin"
+
 var (
 	GinOutPut *LoggerWriter // 声明一个全局变量 GinOutPut，类型为自定义的 LoggerWriter
 )
@@ -14,6 +17,8 @@ var (
 func init() {
 	// 初始化 GinOutPut，默认使用 Debug 日志级别和 DefaultLogger（假设已经在其他文件定义）
 	GinOutPut = NewWriter(DefaultLogger, zapcore.DebugLevel)
+	// 注册到日志管理服务，这样 gin 访问日志的级别可以通过 PUT /levels/gin 单独调整。
+	RegisterLevelModule(_moduleGin, GinOutPut.Level.Level, GinOutPut.Level.SetLevel)
 }
 func GetGinLogger(conf ...gin.LoggerConfig) gin.HandlerFunc {
 	if len(conf) == 0 {
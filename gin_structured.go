@@ -0,0 +1,210 @@
+package zlog
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"go.uber.org/zap"
+)
+
+const (
+	_headerRequestID   = "X-Request-Id"
+	_headerTraceParent = "traceparent"
+)
+
+// ginStructuredOptions 控制 GinStructuredLogger 的行为，通过 GinStructuredLoggerOption 设置。
+type ginStructuredOptions struct {
+	logger      *zap.Logger
+	skipPaths   map[string]struct{}
+	maxBodySize int
+	captureBody bool
+	allowPaths  map[string]struct{}
+	sampleRate  float64 // 2xx/3xx 按此比例采样，0~1，>=400 的响应始终全量记录
+}
+
+// GinStructuredLoggerOption 用于定制 GinStructuredLogger 的行为。
+type GinStructuredLoggerOption func(*ginStructuredOptions)
+
+// WithGinLogger 指定使用的 zap.Logger，不设置时使用 DefaultLogger。
+func WithGinLogger(logger *zap.Logger) GinStructuredLoggerOption {
+	return func(o *ginStructuredOptions) {
+		o.logger = logger
+	}
+}
+
+// WithGinSkipPaths 配置不记录日志的路径，常用于健康检查接口。
+func WithGinSkipPaths(paths ...string) GinStructuredLoggerOption {
+	return func(o *ginStructuredOptions) {
+		for _, p := range paths {
+			o.skipPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithGinBodyCapture 开启请求/响应 body 采集，maxSize 限制采集的字节数，
+// allowPaths 为空表示所有路径都采集，否则只采集列表内的路径。
+func WithGinBodyCapture(maxSize int, allowPaths ...string) GinStructuredLoggerOption {
+	return func(o *ginStructuredOptions) {
+		o.captureBody = true
+		o.maxBodySize = maxSize
+		for _, p := range allowPaths {
+			o.allowPaths[p] = struct{}{}
+		}
+	}
+}
+
+// WithGinSampleRate 设置 2xx/3xx 响应的采样比例（0~1），>=400 的响应始终全量记录。
+func WithGinSampleRate(rate float64) GinStructuredLoggerOption {
+	return func(o *ginStructuredOptions) {
+		o.sampleRate = rate
+	}
+}
+
+// bodyWriter 包装 gin.ResponseWriter，在写响应的同时把内容缓存下来用于日志采集，
+// 超过 maxSize 的部分直接丢弃，避免大响应体占用过多内存。
+type bodyWriter struct {
+	gin.ResponseWriter
+	buf     bytes.Buffer
+	maxSize int
+}
+
+func (w *bodyWriter) Write(p []byte) (int, error) {
+	if w.buf.Len() < w.maxSize {
+		remain := w.maxSize - w.buf.Len()
+		if remain > len(p) {
+			remain = len(p)
+		}
+		w.buf.Write(p[:remain])
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// GinStructuredLogger 是 GetGinLogger 的结构化版本：每个请求输出一条携带固定字段的
+// zap 日志，而不是 LogFormatter 拼出的一行 printf 文本，便于按字段检索和对接日志平台。
+func GinStructuredLogger(opts ...GinStructuredLoggerOption) gin.HandlerFunc {
+	o := &ginStructuredOptions{
+		logger:      DefaultLogger,
+		skipPaths:   make(map[string]struct{}),
+		allowPaths:  make(map[string]struct{}),
+		maxBodySize: 4 * 1024,
+		sampleRate:  1,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+
+	return func(c *gin.Context) {
+		path := c.Request.URL.Path
+		if _, skip := o.skipPaths[path]; skip {
+			c.Next()
+			return
+		}
+
+		traceID := extractTraceID(c.Request)
+		c.Writer.Header().Set(_headerRequestID, traceID)
+
+		var reqBody []byte
+		allowBody := o.captureBody && (len(o.allowPaths) == 0 || hasPath(o.allowPaths, path))
+		if allowBody && c.Request.Body != nil {
+			reqBody, _ = io.ReadAll(io.LimitReader(c.Request.Body, int64(o.maxBodySize)))
+			c.Request.Body = io.NopCloser(io.MultiReader(bytes.NewReader(reqBody), c.Request.Body))
+		}
+
+		var bw *bodyWriter
+		if allowBody {
+			bw = &bodyWriter{ResponseWriter: c.Writer, maxSize: o.maxBodySize}
+			c.Writer = bw
+		}
+
+		start := time.Now()
+		c.Next()
+		latency := time.Since(start)
+
+		status := c.Writer.Status()
+		if status < http.StatusBadRequest && o.sampleRate < 1 && !sampleHit(o.sampleRate) {
+			return
+		}
+
+		fields := []zap.Field{
+			zap.Int("status", status),
+			zap.String("method", c.Request.Method),
+			zap.String("path", path),
+			zap.String("raw_query", c.Request.URL.RawQuery),
+			zap.Float64("latency_ms", float64(latency.Microseconds())/1000),
+			zap.String("client_ip", c.ClientIP()),
+			zap.String("user_agent", c.Request.UserAgent()),
+			zap.String("proto", c.Request.Proto),
+			zap.String("request_id", traceID),
+			zap.String("trace_id", traceID),
+		}
+		if len(c.Errors) > 0 {
+			fields = append(fields, zap.String("error", c.Errors.String()))
+		}
+		if allowBody {
+			fields = append(fields, zap.ByteString("req_body", reqBody))
+			fields = append(fields, zap.ByteString("resp_body", bw.buf.Bytes()))
+		}
+
+		msg := "gin request"
+		switch {
+		case status >= http.StatusInternalServerError:
+			o.logger.Error(msg, fields...)
+		case status >= http.StatusBadRequest:
+			o.logger.Warn(msg, fields...)
+		default:
+			o.logger.Info(msg, fields...)
+		}
+	}
+}
+
+func hasPath(set map[string]struct{}, path string) bool {
+	_, ok := set[path]
+	return ok
+}
+
+// sampleHit 按 rate 做采样判定，rate<=0 表示全部丢弃，rate>=1 由调用方在外层直接放行。
+func sampleHit(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	var b [8]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return true
+	}
+	n := uint64(0)
+	for _, v := range b {
+		n = n<<8 | uint64(v)
+	}
+	return float64(n%1_000_000)/1_000_000 < rate
+}
+
+// extractTraceID 优先复用客户端传来的 X-Request-Id，其次解析 W3C traceparent 里的 trace-id，
+// 都没有的话生成一个新的，保证每个请求都有唯一标识可供排查。
+func extractTraceID(r *http.Request) string {
+	if id := r.Header.Get(_headerRequestID); id != "" {
+		return id
+	}
+	// traceparent 格式：{version}-{trace-id}-{parent-id}-{flags}
+	if tp := r.Header.Get(_headerTraceParent); tp != "" {
+		parts := strings.Split(tp, "-")
+		if len(parts) >= 2 && len(parts[1]) == 32 {
+			return parts[1]
+		}
+	}
+	return newTraceID()
+}
+
+// newTraceID 生成一个 16 字节的随机 id，编码成 32 位十六进制字符串，长度与 W3C trace-id 一致。
+func newTraceID() string {
+	var b [16]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return ""
+	}
+	return hex.EncodeToString(b[:])
+}
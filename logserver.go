@@ -0,0 +1,182 @@
+package zlog
+
+import (
+	"bytes"
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+const _moduleMain = "main"
+
+// moduleLevel 描述一个可以动态调整日志级别的模块：GetLevel 读取当前级别，SetLevel 原子地切换级别。
+// RedisLogger、GinOutPut 这类包级单例在自己的 init 里调用 RegisterLevelModule 挂载进来，
+// 统一由日志管理服务调控；ZapWriter 没有包级单例（每次 NewZapWriter 都是调用方自己持有的实例），
+// 想要同样被 /levels 管控的话，调用方需要在拿到实例后自己调用一次 RegisterLevelModule。
+type moduleLevel struct {
+	GetLevel func() zapcore.Level
+	SetLevel func(zapcore.Level)
+}
+
+var (
+	_modulesMu sync.RWMutex
+	_modules   = map[string]moduleLevel{}
+)
+
+// RegisterLevelModule 把一个可动态调级的模块注册到日志管理服务，
+// name 会出现在 GET /levels 的返回结果里，也是 PUT /levels/{name} 的路径参数。
+func RegisterLevelModule(name string, get func() zapcore.Level, set func(zapcore.Level)) {
+	_modulesMu.Lock()
+	defer _modulesMu.Unlock()
+	_modules[name] = moduleLevel{GetLevel: get, SetLevel: set}
+}
+
+// InitLogServer 启动日志管理 HTTP 服务。除了 /level（zap.AtomicLevel 自带的 GET/PUT handler，
+// 保留用于兼容旧用法）外，还提供：
+//
+//	GET  /levels          列出所有已注册模块当前的日志级别
+//	PUT  /levels/{module}  修改某个模块的日志级别，body 为级别名，如 "debug"
+//	POST /rotate           强制触发一次 lumberjack 切割（仅 file 模式有效）
+//	GET  /config           dump 当前生效的 Config，便于线上排查
+//	GET  /healthz          存活探针
+//
+// 配置了 LogServerToken 时，除 /healthz 外的接口都要求 Authorization: Bearer <token>；
+// LogServerAddr 为空时沿用历史行为只监听 127.0.0.1。
+func (lc *Config) InitLogServer(port int32) {
+	RegisterLevelModule(_moduleMain, lc.Level.Level, lc.Level.SetLevel)
+
+	addr := lc.LogServerAddr
+	if addr == "" {
+		addr = "127.0.0.1"
+	}
+
+	mux := http.NewServeMux()
+	mux.Handle("/level", lc.Level)
+	mux.HandleFunc("/levels", lc.handleLevels)
+	mux.HandleFunc("/levels/", lc.handleModuleLevel)
+	mux.HandleFunc("/rotate", lc.handleRotate)
+	mux.HandleFunc("/config", lc.handleConfig)
+	mux.HandleFunc("/healthz", handleHealthz)
+
+	handler := withLogServerAuth(lc.LogServerToken, mux)
+
+	go func(a string) {
+		_once.Do(func() { // 确保只初始化一次
+			if err := http.ListenAndServe(fmt.Sprintf("%s:%d", a, port), handler); err != nil {
+				zap.S().Error("init log server start failed", zap.Error(err))
+			}
+		})
+	}(addr)
+}
+
+// withLogServerAuth 配置了 token 时要求请求携带匹配的 Authorization: Bearer <token>，
+// 健康检查接口不受限制，方便探活。
+func withLogServerAuth(token string, next http.Handler) http.Handler {
+	if token == "" {
+		return next
+	}
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/healthz" {
+			next.ServeHTTP(w, r)
+			return
+		}
+		want := "Bearer " + token
+		got := r.Header.Get("Authorization")
+		if len(got) != len(want) || subtle.ConstantTimeCompare([]byte(got), []byte(want)) != 1 {
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// handleLevels 返回已注册的每个模块当前的日志级别。
+func (lc *Config) handleLevels(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	_modulesMu.RLock()
+	levels := make(map[string]string, len(_modules))
+	for name, m := range _modules {
+		levels[name] = m.GetLevel().String()
+	}
+	_modulesMu.RUnlock()
+	writeJSON(w, levels)
+}
+
+// handleModuleLevel 处理 PUT /levels/{module}，请求体是级别名，如 "debug"、"warn"。
+func (lc *Config) handleModuleLevel(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPut {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	name := strings.TrimPrefix(r.URL.Path, "/levels/")
+	if name == "" {
+		http.Error(w, "module name required", http.StatusBadRequest)
+		return
+	}
+	_modulesMu.RLock()
+	m, ok := _modules[name]
+	_modulesMu.RUnlock()
+	if !ok {
+		http.Error(w, "module not found", http.StatusNotFound)
+		return
+	}
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var level zapcore.Level
+	if err := level.UnmarshalText(bytes.TrimSpace(body)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	m.SetLevel(level)
+}
+
+// handleRotate 对 Build 里实际创建的每一个写入器（lc.rotators）强制触发一次切割，仅 file 模式有意义。
+func (lc *Config) handleRotate(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	if lc.Mode != _file {
+		http.Error(w, "rotate only supported in file mode", http.StatusBadRequest)
+		return
+	}
+	for _, rt := range lc.rotators {
+		if err := rt.Rotate(); err != nil {
+			http.Error(w, err.Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+}
+
+// handleConfig 返回生效的 Config，便于线上排查配置是否符合预期；敏感字段会被 redacted 抹掉。
+func (lc *Config) handleConfig(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	writeJSON(w, lc.redacted())
+}
+
+// handleHealthz 是最基础的存活探针。
+func handleHealthz(w http.ResponseWriter, r *http.Request) {
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write([]byte("ok"))
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(v)
+}
@@ -0,0 +1,64 @@
+package zlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// LokiSinkConfig 配置 Grafana Loki 的 push 地址，以及附加在每条日志流上的标签。
+type LokiSinkConfig struct {
+	PushURL string            `mapstructure:"pushUrl"`
+	Labels  map[string]string `json:",optional" mapstructure:"labels"`
+}
+
+// lokiSink 通过 Loki 的 push API 推送日志。
+type lokiSink struct {
+	url    string
+	labels map[string]string
+	client *http.Client
+}
+
+func newLokiSink(conf *SinkConfig) (sinkBackend, error) {
+	if conf.Loki == nil || conf.Loki.PushURL == "" {
+		return nil, fmt.Errorf("zlog: loki sink requires pushUrl")
+	}
+	return &lokiSink{
+		url:    conf.Loki.PushURL,
+		labels: conf.Loki.Labels,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 按 Loki push API 的格式（streams[].stream 为标签，values 为 [时间戳, 内容] 对）推送一批日志行。
+func (s *lokiSink) Send(entries []string) error {
+	now := strconv.FormatInt(time.Now().UnixNano(), 10)
+	values := make([][2]string, 0, len(entries))
+	for _, e := range entries {
+		values = append(values, [2]string{now, e})
+	}
+	payload := map[string]interface{}{
+		"streams": []map[string]interface{}{
+			{
+				"stream": s.labels,
+				"values": values,
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.url, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("zlog: loki push returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,60 @@
+package zlog
+
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// requestIDCtxKey 是存放 X-Request-Id 的 context key 类型，避免和其他包的 key 冲突。
+type requestIDCtxKey struct{}
+
+// WithRequestID 把请求 id 存进 context，在没有激活 OTel span 的场景下，
+// Ctx/CtxS 会退化使用它来做日志关联。
+func WithRequestID(ctx context.Context, requestID string) context.Context {
+	return context.WithValue(ctx, requestIDCtxKey{}, requestID)
+}
+
+// Ctx 从 context 中提取 OTel SpanContext，返回一个附带 trace_id/span_id 字段的 zap.Logger，
+// 没有激活的 span 时退化使用 WithRequestID 存进 context 的 X-Request-Id，方便按请求关联日志和链路。
+// 如果 ctx 里有正在记录的 span，还会用 TracingCore 包一层，达到 _tracingThreshold 的日志会
+// 自动记成这个 span 上的 event（error 级别还会把 span 标成 Error 状态），调用方不需要
+// 在业务代码里重复打点。
+func Ctx(ctx context.Context) *zap.Logger {
+	logger := DefaultLogger
+	if fields := ctxFields(ctx); len(fields) > 0 {
+		logger = logger.With(fields...)
+	}
+	if span := trace.SpanFromContext(ctx); span.SpanContext().IsValid() {
+		logger = logger.WithOptions(zap.WrapCore(func(core zapcore.Core) zapcore.Core {
+			return NewTracingCore(core, span, _tracingThreshold)
+		}))
+	}
+	return logger
+}
+
+// CtxS 是 Ctx 的 Sugared 版本。
+func CtxS(ctx context.Context) *zap.SugaredLogger {
+	return Ctx(ctx).Sugar()
+}
+
+// ctxFields 从 ctx 里提取可用于日志关联的字段。
+func ctxFields(ctx context.Context) []zap.Field {
+	sc := trace.SpanContextFromContext(ctx)
+	if sc.IsValid() {
+		fields := []zap.Field{
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		}
+		if sc.TraceState().Len() > 0 {
+			fields = append(fields, zap.String("trace_state", sc.TraceState().String()))
+		}
+		return fields
+	}
+	if id, ok := ctx.Value(requestIDCtxKey{}).(string); ok && id != "" {
+		return []zap.Field{zap.String("request_id", id)}
+	}
+	return nil
+}
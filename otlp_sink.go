@@ -0,0 +1,63 @@
+package zlog
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// OTLPSinkConfig 配置 OTLP 日志导出的目标地址，走 OTLP/HTTP 协议推送。
+type OTLPSinkConfig struct {
+	Endpoint string `mapstructure:"endpoint"`
+	Insecure bool   `json:",optional" mapstructure:"insecure"`
+}
+
+// otlpSink 把日志行转成 OTLP logs 的 JSON 形式推给 collector。
+type otlpSink struct {
+	endpoint string
+	client   *http.Client
+}
+
+func newOTLPSink(conf *SinkConfig) (sinkBackend, error) {
+	if conf.OTLP == nil || conf.OTLP.Endpoint == "" {
+		return nil, fmt.Errorf("zlog: otlp sink requires endpoint")
+	}
+	return &otlpSink{
+		endpoint: conf.OTLP.Endpoint,
+		client:   &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行包成 OTLP logs 最简化的 resourceLogs/scopeLogs/logRecords 结构推给 collector。
+func (s *otlpSink) Send(entries []string) error {
+	records := make([]map[string]interface{}, 0, len(entries))
+	for _, e := range entries {
+		records = append(records, map[string]interface{}{
+			"body": map[string]string{"stringValue": e},
+		})
+	}
+	payload := map[string]interface{}{
+		"resourceLogs": []map[string]interface{}{
+			{
+				"scopeLogs": []map[string]interface{}{
+					{"logRecords": records},
+				},
+			},
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := s.client.Post(s.endpoint, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("zlog: otlp endpoint returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+package zlog
+
+import (
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// _bucketIdleTTL 是一个分桶令牌桶在无人访问多久之后会被回收，避免高基数 PerKey
+// （比如按 trace_id 分桶）下 buckets 无限增长导致内存泄漏。
+const _bucketIdleTTL = 10 * time.Minute
+
+// rateLimitState 是 rateLimitCore 真正持有的可变状态，由 With 派生出的多个
+// rateLimitCore 实例共享同一份，这样分桶计数和丢弃统计才不会因为 With 而被拆散。
+type rateLimitState struct {
+	conf    *RateLimitConfig
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+	dropped map[string]int
+}
+
+// rateLimitCore 包装一个 zapcore.Core，按 PerKey 对应的字段值（或消息本身）分桶做令牌桶限流，
+// 避免热循环里反复出现的相同错误把磁盘或上报后端打爆。
+type rateLimitCore struct {
+	zapcore.Core
+	state *rateLimitState
+}
+
+// newRateLimitState 创建限流共享状态，并启动一个后台协程周期性地把各个 key 被丢弃的条数打成
+// 一条 info 日志、顺带淘汰空闲分桶。一份 Config.Build 只应该调用一次，之后用 wrap 把它套到
+// 每个子 core 上，这样多个子 core 共享同一份分桶计数和丢弃统计，也只有一个上报协程。
+func newRateLimitState(conf *RateLimitConfig) *rateLimitState {
+	state := &rateLimitState{
+		conf:    conf,
+		buckets: make(map[string]*tokenBucket),
+		dropped: make(map[string]int),
+	}
+	go state.reportDropped()
+	return state
+}
+
+// wrap 用共享的限流状态包装一个子 core。必须对 Tee 合并前的每个子 core 分别调用 wrap，
+// 不能对合并后的 Tee 调用：zapcore.Tee 的 Write 不做任何级别过滤（过滤只发生在 Check 阶段），
+// 如果把 rateLimitCore 套在 Tee 外层，Write 会直接转发给 Tee.Write，对所有子 core 无差别写入，
+// 等于绕过了它们各自的 LevelEnabler（比如一条 Info 日志会被写进只收 Error 的错误文件 core）。
+func (s *rateLimitState) wrap(core zapcore.Core) zapcore.Core {
+	return &rateLimitCore{Core: core, state: s}
+}
+
+// Check 必须委托给被包装的 core 的 Check，而不是只看 Enabled：像 zapcore.NewSamplerWithOptions
+// 这样的采样 core 把丢弃决定做在 Check 里而不是 Write 里，只比较 Enabled 会完全绕过采样逻辑。
+// 这里用一个独立的探测用 CheckedEntry（传 nil）问一下内层 core 是否放行，不把内层 core 自己
+// 加进真正的 ce，否则 Write 会被内层和 rateLimitCore 各调用一次、日志重复输出两份。
+func (c *rateLimitCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if probe := c.Core.Check(ent, nil); probe != nil {
+		return ce.AddCore(ent, c)
+	}
+	return ce
+}
+
+// Write 先做限流判断，被限流的日志直接丢弃（计入 dropped 统计），否则交给原 core 真正写入。
+func (c *rateLimitCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	key := c.state.key(ent, fields)
+	if !c.state.allow(key) {
+		c.state.mu.Lock()
+		c.state.dropped[key]++
+		c.state.mu.Unlock()
+		return nil
+	}
+	return c.Core.Write(ent, fields)
+}
+
+// With 返回一个携带额外字段的新 rateLimitCore，限流状态仍然共享。
+func (c *rateLimitCore) With(fields []zapcore.Field) zapcore.Core {
+	return &rateLimitCore{Core: c.Core.With(fields), state: c.state}
+}
+
+// key 决定限流分桶：配置了 PerKey 时按该字段的值分桶，否则按日志消息本身分桶。
+func (s *rateLimitState) key(ent zapcore.Entry, fields []zapcore.Field) string {
+	if s.conf.PerKey == "" {
+		return ent.Message
+	}
+	for _, f := range fields {
+		if f.Key == s.conf.PerKey {
+			return fieldToString(f)
+		}
+	}
+	return ent.Message
+}
+
+func (s *rateLimitState) allow(key string) bool {
+	s.mu.Lock()
+	b, ok := s.buckets[key]
+	if !ok {
+		b = newTokenBucket(s.conf.PerSecond, s.conf.Burst)
+		s.buckets[key] = b
+	}
+	s.mu.Unlock()
+	return b.Allow()
+}
+
+// reportDropped 周期性地把每个 key 被丢弃的条数打成一条 info 日志（走 zap，而不是标准库 log，
+// 这样丢弃统计本身也会出现在统一的日志输出里），同时顺带淘汰掉长时间没有被访问的分桶。
+func (s *rateLimitState) reportDropped() {
+	ticker := time.NewTicker(30 * time.Second)
+	defer ticker.Stop()
+	for range ticker.C {
+		s.mu.Lock()
+		for key, n := range s.dropped {
+			if n > 0 {
+				zap.S().Infof("dropped %d duplicate entries for %q in last 30s", n, key)
+			}
+		}
+		s.dropped = make(map[string]int)
+		for key, b := range s.buckets {
+			if b.idleSince() > _bucketIdleTTL {
+				delete(s.buckets, key)
+			}
+		}
+		s.mu.Unlock()
+	}
+}
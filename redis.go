@@ -2,9 +2,14 @@ package zlog
 
 import (
 	"context"
+
 	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
 )
 
+// _moduleRedis 是 RedisLogger 在日志管理服务里注册的模块名，对应 PUT /levels/redis。
+const _moduleRedis = "redis"
+
 var (
 	RedisLogger *redisLogger
 )
@@ -13,19 +18,35 @@ func init() {
 	//初始化全局变量RedisLogger
 	RedisLogger = &redisLogger{
 		logger: DefaultLogger.With(zap.String("module", RedisModuleKey)).Sugar(),
+		level:  zap.NewAtomicLevelAt(zapcore.InfoLevel),
 	}
 	//使用全局的 DefaultLogger，为 Redis 日志添加一个模块标识字段 module，值为 redis。
 	//调用了 zap.Logger.With 方法，为所有日志条目动态添加 module: redis 键值对。
 	//通过 Sugar 方法将 zap.Logger 转为 zap.SugaredLogger，支持格式化输出。
+	// 注册到日志管理服务，这样 redis 命令日志可以通过 PUT /levels/redis 单独调整，不需要跟主 Level 联动。
+	RegisterLevelModule(_moduleRedis, RedisLogger.level.Level, RedisLogger.level.SetLevel)
 }
 
 // 它是一个专门用于记录 Redis 日志的封装组件，提供方法打印格式化日志和动态更新日志实例。
 type redisLogger struct {
 	logger *zap.SugaredLogger
+	level  zap.AtomicLevel // 独立于主 Level 的模块级别，由 RegisterLevelModule 挂到 /levels/redis 上
 }
 
 func (rl *redisLogger) Printf(ctx context.Context, format string, v ...interface{}) {
-	rl.logger.Infof(format, v...)
+	if !rl.level.Enabled(zapcore.InfoLevel) {
+		return
+	}
+	logger := rl.logger
+	// 把 ctx 里的 trace_id/span_id（或退化的 request_id）带上，这样 Redis 命令日志也能和请求/链路对上。
+	if fields := ctxFields(ctx); len(fields) > 0 {
+		args := make([]interface{}, len(fields))
+		for i, f := range fields {
+			args[i] = f
+		}
+		logger = logger.With(args...)
+	}
+	logger.Infof(format, v...)
 }
 
 // Update 功能：
@@ -0,0 +1,114 @@
+package report
+
+import (
+	"log"
+	"sync"
+	"time"
+)
+
+const (
+	_defaultFlushSec = 5
+	_defaultMaxCount = 20
+	_maxRetry        = 3
+)
+
+// batchWriter 把写入的日志行累积起来，按 FlushSec 或 MaxCount（先到者触发）批量转发给 Reporter，
+// 避免每条日志都单独请求一次 IM 接口导致触发限流甚至被封号。它实现了 zapcore.WriteSyncer，
+// 可以直接塞进 zapcore.NewCore 当作上报通道的写入器。
+type batchWriter struct {
+	mu       sync.Mutex
+	buf      []string
+	reporter Reporter
+	maxCount int
+	limiter  *rateLimiter
+
+	flushCh chan struct{}
+	closeCh chan struct{}
+}
+
+func newBatchWriter(reporter Reporter, conf *ReportConfig) *batchWriter {
+	flushSec := conf.FlushSec
+	if flushSec <= 0 {
+		flushSec = _defaultFlushSec
+	}
+	maxCount := conf.MaxCount
+	if maxCount <= 0 {
+		maxCount = _defaultMaxCount
+	}
+	w := &batchWriter{
+		reporter: reporter,
+		maxCount: maxCount,
+		limiter:  newRateLimiter(conf.RateLimit),
+		flushCh:  make(chan struct{}, 1),
+		closeCh:  make(chan struct{}),
+	}
+	go w.run(time.Duration(flushSec) * time.Second)
+	return w
+}
+
+// Write 实现 zapcore.WriteSyncer，缓冲区达到 MaxCount 时触发一次提前刷新。
+func (w *batchWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	w.buf = append(w.buf, string(p))
+	full := len(w.buf) >= w.maxCount
+	w.mu.Unlock()
+	if full {
+		select {
+		case w.flushCh <- struct{}{}:
+		default:
+		}
+	}
+	return len(p), nil
+}
+
+// Sync 立即触发一次刷新，满足 zapcore.WriteSyncer 接口。
+func (w *batchWriter) Sync() error {
+	w.flush()
+	return nil
+}
+
+// run 按 FlushSec 定时刷新，也响应 Write 触发的提前刷新。
+func (w *batchWriter) run(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			w.flush()
+		case <-w.flushCh:
+			w.flush()
+		case <-w.closeCh:
+			w.flush()
+			return
+		}
+	}
+}
+
+// flush 取出当前缓冲的全部日志行，限流后发送，失败按指数退避重试几次后放弃。
+func (w *batchWriter) flush() {
+	w.mu.Lock()
+	if len(w.buf) == 0 {
+		w.mu.Unlock()
+		return
+	}
+	messages := w.buf
+	w.buf = nil
+	w.mu.Unlock()
+
+	w.limiter.Wait()
+	backoff := time.Second
+	for attempt := 1; attempt <= _maxRetry; attempt++ {
+		if err := w.reporter.Send(messages); err != nil {
+			log.Printf("zlog: report send failed (attempt %d/%d): %v", attempt, _maxRetry, err)
+			time.Sleep(backoff)
+			backoff *= 2
+			continue
+		}
+		return
+	}
+}
+
+// Close 停止后台的定时刷新协程，会先做一次最终刷新，避免丢失最后一批日志。
+func (w *batchWriter) Close() {
+	close(w.closeCh)
+}
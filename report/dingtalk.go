@@ -0,0 +1,83 @@
+package report
+
+import (
+	"bytes"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const _typeDingtalk = "dingtalk"
+
+func init() {
+	RegisterReporter(_typeDingtalk, newDingtalkReporter)
+}
+
+// dingtalkReporter 通过钉钉自定义机器人 Webhook 推送文本消息，支持可选的加签校验。
+type dingtalkReporter struct {
+	webhook string
+	secret  string
+	client  *http.Client
+}
+
+func newDingtalkReporter(conf *ReportConfig) (Reporter, error) {
+	if conf.Webhook == "" {
+		return nil, fmt.Errorf("report: dingtalk webhook is empty")
+	}
+	return &dingtalkReporter{
+		webhook: conf.Webhook,
+		secret:  conf.Secret,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行拼成一条文本消息，通过钉钉机器人 Webhook 发出去，
+// 如果配置了 Secret，按钉钉加签规则在 Webhook 后追加 timestamp 和 sign。
+func (r *dingtalkReporter) Send(messages []string) error {
+	payload := map[string]interface{}{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": strings.Join(messages, "\n"),
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	webhook := r.webhook
+	if r.secret != "" {
+		webhook, err = r.signedWebhook()
+		if err != nil {
+			return err
+		}
+	}
+	resp, err := r.client.Post(webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: dingtalk webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
+
+// signedWebhook 按钉钉加签规则计算 timestamp+"\n"+secret 的 HMAC-SHA256，
+// 并把 timestamp 和 base64 后的签名拼到 Webhook 地址上。
+func (r *dingtalkReporter) signedWebhook() (string, error) {
+	timestamp := strconv.FormatInt(time.Now().UnixMilli(), 10)
+	stringToSign := timestamp + "\n" + r.secret
+	h := hmac.New(sha256.New, []byte(r.secret))
+	if _, err := h.Write([]byte(stringToSign)); err != nil {
+		return "", err
+	}
+	sign := base64.StdEncoding.EncodeToString(h.Sum(nil))
+	return fmt.Sprintf("%s&timestamp=%s&sign=%s", r.webhook, timestamp, url.QueryEscape(sign)), nil
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const _typeFeishu = "feishu"
+
+func init() {
+	RegisterReporter(_typeFeishu, newFeishuReporter)
+}
+
+// feishuReporter 通过飞书/Lark 自定义机器人 Webhook 推送文本消息。
+type feishuReporter struct {
+	webhook string
+	client  *http.Client
+}
+
+func newFeishuReporter(conf *ReportConfig) (Reporter, error) {
+	if conf.Webhook == "" {
+		return nil, fmt.Errorf("report: feishu webhook is empty")
+	}
+	return &feishuReporter{
+		webhook: conf.Webhook,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行拼成一条文本消息，通过飞书机器人 Webhook 发出去。
+func (r *feishuReporter) Send(messages []string) error {
+	payload := map[string]interface{}{
+		"msg_type": "text",
+		"content": map[string]string{
+			"text": strings.Join(messages, "\n"),
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(r.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: feishu webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
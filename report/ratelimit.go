@@ -0,0 +1,35 @@
+package report
+
+import "time"
+
+// rateLimiter 是一个简单的令牌桶限流器，控制每秒最多发送多少次上报请求，
+// 避免日志风暴时连续调用 IM 机器人接口触发频率限制甚至被封号。
+type rateLimiter struct {
+	ticker *time.Ticker
+	tokens chan struct{}
+}
+
+func newRateLimiter(perSecond int) *rateLimiter {
+	if perSecond <= 0 {
+		perSecond = 1
+	}
+	r := &rateLimiter{
+		ticker: time.NewTicker(time.Second / time.Duration(perSecond)),
+		tokens: make(chan struct{}, 1),
+	}
+	r.tokens <- struct{}{} // 先放一个令牌，首次发送不用等
+	go func() {
+		for range r.ticker.C {
+			select {
+			case r.tokens <- struct{}{}:
+			default:
+			}
+		}
+	}()
+	return r
+}
+
+// Wait 阻塞直到拿到一个令牌。
+func (r *rateLimiter) Wait() {
+	<-r.tokens
+}
@@ -0,0 +1,58 @@
+package report
+
+import (
+	"go.uber.org/zap"
+)
+
+// ReportConfig 日志上报配置。Type 决定从已注册的 Reporter 中选择哪一个后端，
+// Webhook/Secret/Token/ChatID 按所选后端各取所需，未用到的字段留空即可。
+type ReportConfig struct {
+	Type      string          `json:",optional" mapstructure:"type"`       // feishu、wecom、telegram、slack、dingtalk
+	Webhook   string          `json:",optional" mapstructure:"webhook"`    // feishu/wecom/slack/dingtalk 的 webhook 地址
+	Secret    string          `json:",optional" mapstructure:"secret"`     // dingtalk 加签密钥
+	Token     string          `json:",optional" mapstructure:"token"`      // telegram bot token
+	ChatID    string          `json:",optional" mapstructure:"chatId"`     // telegram chat id
+	Level     zap.AtomicLevel `json:"Level" mapstructure:"level"`          // 达到此级别才上报，默认 warn
+	FlushSec  int             `json:",default=5" mapstructure:"flushSec"`  // 批量上报的刷新间隔（秒）
+	MaxCount  int             `json:",default=20" mapstructure:"maxCount"` // 批量上报的最大条数，FlushSec 和 MaxCount 先到者触发
+	RateLimit int             `json:",default=1" mapstructure:"rateLimit"` // 每秒最多发送多少次请求，避免机器人被限流/封号
+}
+
+// Reporter 是所有 IM 上报后端的统一接口，每种渠道只需要实现批量发送即可。
+type Reporter interface {
+	// Send 把一批已经渲染好的日志行发送给对应的 IM 渠道。
+	Send(messages []string) error
+}
+
+// ReporterFactory 根据 ReportConfig 创建一个 Reporter 实例。
+type ReporterFactory func(conf *ReportConfig) (Reporter, error)
+
+var reporterFactories = map[string]ReporterFactory{}
+
+// RegisterReporter 注册一个上报后端，key 对应 ReportConfig.Type。
+// 内置的 feishu/wecom/telegram/slack/dingtalk 都是通过这种方式注册的，
+// 用户接入自定义渠道时也在自己包的 init 里调用它即可。
+func RegisterReporter(name string, factory ReporterFactory) {
+	reporterFactories[name] = factory
+}
+
+// NewReportWriterBuffer 依据 conf.Type 选择已注册的 Reporter，
+// 并在外面包一层批处理+限流，返回的 writer 可以直接作为 zapcore.WriteSyncer 使用。
+func NewReportWriterBuffer(conf *ReportConfig) *batchWriter {
+	factory, ok := reporterFactories[conf.Type]
+	if !ok {
+		// 历史上只支持飞书，未配置 Type 时按飞书处理保持兼容
+		factory = reporterFactories[_typeFeishu]
+	}
+	reporter, err := factory(conf)
+	if err != nil {
+		// 上报通道建立失败不应该影响主日志链路，退化为丢弃上报内容
+		reporter = noopReporter{}
+	}
+	return newBatchWriter(reporter, conf)
+}
+
+// noopReporter 在 Reporter 初始化失败时兜底，避免 nil 指针。
+type noopReporter struct{}
+
+func (noopReporter) Send([]string) error { return nil }
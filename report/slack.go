@@ -0,0 +1,52 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const _typeSlack = "slack"
+
+func init() {
+	RegisterReporter(_typeSlack, newSlackReporter)
+}
+
+// slackReporter 通过 Slack Incoming Webhook 推送文本消息。
+type slackReporter struct {
+	webhook string
+	client  *http.Client
+}
+
+func newSlackReporter(conf *ReportConfig) (Reporter, error) {
+	if conf.Webhook == "" {
+		return nil, fmt.Errorf("report: slack webhook is empty")
+	}
+	return &slackReporter{
+		webhook: conf.Webhook,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行拼成一条文本消息，通过 Slack Incoming Webhook 发出去。
+func (r *slackReporter) Send(messages []string) error {
+	payload := map[string]string{
+		"text": strings.Join(messages, "\n"),
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(r.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: slack webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
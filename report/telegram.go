@@ -0,0 +1,51 @@
+package report
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const _typeTelegram = "telegram"
+
+func init() {
+	RegisterReporter(_typeTelegram, newTelegramReporter)
+}
+
+// telegramReporter 通过 Telegram Bot API 的 sendMessage 接口推送文本消息。
+type telegramReporter struct {
+	token  string
+	chatID string
+	client *http.Client
+}
+
+func newTelegramReporter(conf *ReportConfig) (Reporter, error) {
+	if conf.Token == "" || conf.ChatID == "" {
+		return nil, fmt.Errorf("report: telegram token or chatId is empty")
+	}
+	return &telegramReporter{
+		token:  conf.Token,
+		chatID: conf.ChatID,
+		client: &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行拼成一条消息，调用 Telegram Bot API 发送给指定会话。
+func (r *telegramReporter) Send(messages []string) error {
+	api := fmt.Sprintf("https://api.telegram.org/bot%s/sendMessage", r.token)
+	form := url.Values{
+		"chat_id": {r.chatID},
+		"text":    {strings.Join(messages, "\n")},
+	}
+	resp, err := r.client.PostForm(api, form)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: telegram api returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,55 @@
+package report
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const _typeWecom = "wecom"
+
+func init() {
+	RegisterReporter(_typeWecom, newWecomReporter)
+}
+
+// wecomReporter 通过企业微信群机器人 Webhook 推送文本消息。
+type wecomReporter struct {
+	webhook string
+	client  *http.Client
+}
+
+func newWecomReporter(conf *ReportConfig) (Reporter, error) {
+	if conf.Webhook == "" {
+		return nil, fmt.Errorf("report: wecom webhook is empty")
+	}
+	return &wecomReporter{
+		webhook: conf.Webhook,
+		client:  &http.Client{Timeout: 5 * time.Second},
+	}, nil
+}
+
+// Send 把一批日志行拼成一条文本消息，通过企业微信机器人 Webhook 发出去。
+func (r *wecomReporter) Send(messages []string) error {
+	payload := map[string]interface{}{
+		"msgtype": "text",
+		"text": map[string]string{
+			"content": strings.Join(messages, "\n"),
+		},
+	}
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	resp, err := r.client.Post(r.webhook, "application/json", bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode >= 300 {
+		return fmt.Errorf("report: wecom webhook returned status %d", resp.StatusCode)
+	}
+	return nil
+}
@@ -0,0 +1,98 @@
+package zlog
+
+import (
+	"fmt"
+	"log"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// timedRotateWriter 在 lumberjack 按大小/备份数切割的基础上，叠加按时间切割的能力。
+// 它内部持有一个 *lumberjack.Logger 做真正的写入，后台协程根据 interval 或 at
+// 计算下一个切割时间点，到点后调用 Rotate，lumberjack 会把当前文件关闭并按照
+// 自己的命名规则（文件名中带上时间戳）归档，从而实现按小时/按天/在固定时间点切割日志。
+type timedRotateWriter struct {
+	mu       sync.Mutex
+	lj       *lumberjack.Logger
+	interval time.Duration
+	at       string // HH:MM，配置了此项时优先按每天固定时间点切割，否则按 interval 周期切割
+	stopCh   chan struct{}
+}
+
+// newTimedRotateWriter 创建一个按时间切割的 WriteSyncer，写入仍然委托给 lumberjack。
+func newTimedRotateWriter(lj *lumberjack.Logger, interval time.Duration, at string) *timedRotateWriter {
+	w := &timedRotateWriter{
+		lj:       lj,
+		interval: interval,
+		at:       at,
+		stopCh:   make(chan struct{}),
+	}
+	go w.run()
+	return w
+}
+
+// Write 实现 zapcore.WriteSyncer，加锁避免和后台的 rotate 并发操作文件。
+func (w *timedRotateWriter) Write(p []byte) (int, error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lj.Write(p)
+}
+
+// Sync 满足 zapcore.WriteSyncer 接口，lumberjack 本身没有需要刷新的缓冲区。
+func (w *timedRotateWriter) Sync() error {
+	return nil
+}
+
+// run 不停计算下一次切割的时间点并等待，到点后执行切割。
+func (w *timedRotateWriter) run() {
+	for {
+		timer := time.NewTimer(w.nextDuration())
+		select {
+		case <-timer.C:
+			if err := w.Rotate(); err != nil {
+				log.Printf("zlog: timed rotate failed: %v", err)
+			}
+		case <-w.stopCh:
+			timer.Stop()
+			return
+		}
+	}
+}
+
+// nextDuration 计算距离下一次切割的时长：
+// 配置了 at 时按每天固定时间点（今天已过则顺延到明天）；否则按 interval 周期切割，
+// interval 未设置时兜底为 24 小时。
+func (w *timedRotateWriter) nextDuration() time.Duration {
+	now := time.Now()
+	if w.at != "" {
+		var hour, minute int
+		if _, err := fmt.Sscanf(strings.TrimSpace(w.at), "%d:%d", &hour, &minute); err == nil {
+			next := time.Date(now.Year(), now.Month(), now.Day(), hour, minute, 0, 0, now.Location())
+			if !next.After(now) {
+				next = next.Add(24 * time.Hour)
+			}
+			return next.Sub(now)
+		}
+		log.Printf("zlog: invalid rotateAt %q, fallback to rotateInterval", w.at)
+	}
+	if w.interval <= 0 {
+		return 24 * time.Hour
+	}
+	return w.interval
+}
+
+// Rotate 关闭当前文件并触发 lumberjack 切割，归档文件会按 lumberjack 的规则带上切割时的时间戳。
+// 与 *lumberjack.Logger 同名同签名，实现 rotatable 接口，供 /rotate 管理接口直接调用。
+func (w *timedRotateWriter) Rotate() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	return w.lj.Rotate()
+}
+
+// Stop 停止后台的定时切割协程，一般不需要调用，随进程退出即可。
+func (w *timedRotateWriter) Stop() {
+	close(w.stopCh)
+}
@@ -0,0 +1,58 @@
+package zlog
+
+import (
+	"sync"
+	"time"
+)
+
+// tokenBucket 是一个简单的令牌桶限流器：capacity 个令牌，按 refillRate（每秒）匀速补充。
+type tokenBucket struct {
+	mu         sync.Mutex
+	tokens     float64
+	capacity   float64
+	refillRate float64
+	lastRefill time.Time
+	lastUsed   time.Time // 最近一次被访问的时间，供上层做空闲桶的淘汰
+}
+
+func newTokenBucket(perSecond, burst int) *tokenBucket {
+	if perSecond <= 0 {
+		perSecond = 1
+	}
+	if burst <= 0 {
+		burst = perSecond
+	}
+	now := time.Now()
+	return &tokenBucket{
+		tokens:     float64(burst),
+		capacity:   float64(burst),
+		refillRate: float64(perSecond),
+		lastRefill: now,
+		lastUsed:   now,
+	}
+}
+
+// Allow 尝试消耗一个令牌，成功返回 true；令牌耗尽时返回 false，调用方应当丢弃这条日志。
+func (b *tokenBucket) Allow() bool {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	now := time.Now()
+	b.tokens += now.Sub(b.lastRefill).Seconds() * b.refillRate
+	if b.tokens > b.capacity {
+		b.tokens = b.capacity
+	}
+	b.lastRefill = now
+	b.lastUsed = now
+	if b.tokens < 1 {
+		return false
+	}
+	b.tokens--
+	return true
+}
+
+// idleSince 返回距离上一次 Allow 调用过去了多久，供上层判断这个桶是否可以被淘汰。
+func (b *tokenBucket) idleSince() time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	return time.Since(b.lastUsed)
+}
@@ -0,0 +1,71 @@
+package zlog
+
+import (
+	"fmt"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap/zapcore"
+)
+
+// TracingCore 包装一个已有的 zapcore.Core，日志级别达到 threshold 时，
+// 额外把该条日志记为 span 上的一个 event，error 级别还会把 span 标成 Error 状态，
+// 这样调用方不需要在业务代码里重复打点，错误日志就能直接在链路追踪 UI 里看到。
+type TracingCore struct {
+	zapcore.Core
+	span      trace.Span
+	threshold zapcore.Level
+}
+
+// NewTracingCore 用给定的 span 包装 core，threshold 之上级别的日志才会记为 span event。
+func NewTracingCore(core zapcore.Core, span trace.Span, threshold zapcore.Level) zapcore.Core {
+	return &TracingCore{Core: core, span: span, threshold: threshold}
+}
+
+// _tracingThreshold 是 Ctx/CtxS 绑定 span 时使用的默认 threshold，可以用 SetTracingThreshold 调整。
+var _tracingThreshold = zapcore.ErrorLevel
+
+// SetTracingThreshold 调整 Ctx/CtxS 绑定 span 时使用的 threshold，默认只有 error 及以上级别才会记为 span event。
+func SetTracingThreshold(level zapcore.Level) {
+	_tracingThreshold = level
+}
+
+// Check 记录 span event（如果需要），然后把 ce 原样交给被包装的 core 的 Check 去加子 core，
+// 不把 TracingCore 自己加进 ce。c.Core 包的往往是 Build 出来的整个 Tee（文件、错误文件、
+// 上报、各个 sink 各自有自己的 LevelEnabler），如果把自己加进 ce，之后 Write 就得转发给
+// c.Core.Write；但 zapcore.Tee 的 Write 不做任何级别过滤，会让这条日志无差别写进所有子
+// core，绕过它们各自的级别门槛。直接委托 Check 能让 Tee 把实际应该收到这条日志的子 core
+// 自己加进 ce，各自的 Write 该不该被调用完全不受 TracingCore 影响。
+// 代价是打 span event 时只能拿到 Entry（level/message），拿不到这次调用的字段——字段只有
+// 在 Write 里才知道，而 TracingCore 的 Write 在这种委托方式下不会被调用。
+func (c *TracingCore) Check(ent zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.span != nil && c.span.IsRecording() && ent.Level >= c.threshold {
+		c.span.AddEvent(ent.Message, trace.WithAttributes(attribute.String("level", ent.Level.String())))
+		if ent.Level >= zapcore.ErrorLevel {
+			c.span.SetStatus(codes.Error, ent.Message)
+		}
+	}
+	return c.Core.Check(ent, ce)
+}
+
+// Write 只是满足 zapcore.Core 接口；正常路径下 TracingCore 从不会被加进 CheckedEntry.cores，
+// 所以这个方法不会被调用到，这里只做直通委托防御性兜底。
+func (c *TracingCore) Write(ent zapcore.Entry, fields []zapcore.Field) error {
+	return c.Core.Write(ent, fields)
+}
+
+// With 返回一个携带额外字段的新 TracingCore，保持同一个 span 和 threshold。
+func (c *TracingCore) With(fields []zapcore.Field) zapcore.Core {
+	return &TracingCore{Core: c.Core.With(fields), span: c.span, threshold: c.threshold}
+}
+
+// fieldToString 把一个 zap.Field 的值渲染成字符串，rateLimitState.key 用它按 PerKey 字段的值分桶。
+func fieldToString(f zapcore.Field) string {
+	enc := zapcore.NewMapObjectEncoder()
+	f.AddTo(enc)
+	if v, ok := enc.Fields[f.Key]; ok {
+		return fmt.Sprint(v)
+	}
+	return ""
+}